@@ -0,0 +1,140 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+// EphemeralFunc is a function that takes a value and returns a value
+// identical to its argument but marked with marks.Ephemeral. Unlike
+// marks.Sensitive, this mark is not about redaction. The function only
+// applies the mark: no code in this module rejects an ephemeral value
+// when writing the state or plan file.
+var EphemeralFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		// As with SensitiveFunc, the mark replaces any other shallow mark
+		// the value already carries: an ephemeral value arriving here
+		// with some other non-standard mark would imply a bug elsewhere.
+		val, _ := args[0].Unmark()
+		return val.Mark(marks.Ephemeral), nil
+	},
+})
+
+// NonephemeralFunc is a function that takes a value and returns a value
+// identical to its argument but with the "ephemeral" marking removed, if
+// it was present.
+var NonephemeralFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val, marksOnVal := args[0].Unmark()
+		delete(marksOnVal, marks.Ephemeral)
+		return val.WithMarks(marksOnVal), nil
+	},
+})
+
+// IsEphemeralFunc is a function that takes a value and returns true if
+// and only if that value is marked as ephemeral.
+var IsEphemeralFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.BoolVal(args[0].HasMark(marks.Ephemeral)), nil
+	},
+})
+
+// HasMarkFunc is a function that takes a value and the string name of a
+// mark kind ("sensitive" or "ephemeral") and returns true if and only if
+// the value carries that mark. It generalizes IsSensitiveFunc and
+// IsEphemeralFunc for callers that want to check a mark kind that isn't
+// known until runtime.
+var HasMarkFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+		{
+			Name: "kind",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		kind := args[1].AsString()
+		for _, m := range marks.TypedMarks {
+			if string(m) == kind {
+				return cty.BoolVal(args[0].HasMark(m)), nil
+			}
+		}
+		return cty.NilVal, fmt.Errorf("unsupported mark kind %q", kind)
+	},
+})
+
+// Ephemeral marks a value as ephemeral. It only applies the mark; see
+// EphemeralFunc.
+func Ephemeral(val cty.Value) (cty.Value, error) {
+	return EphemeralFunc.Call([]cty.Value{val})
+}
+
+// Nonephemeral removes the "ephemeral" mark from a value, if it was
+// present, leaving any other marks untouched.
+func Nonephemeral(val cty.Value) (cty.Value, error) {
+	return NonephemeralFunc.Call([]cty.Value{val})
+}
+
+// IsEphemeral returns true if and only if the given value is marked as
+// ephemeral.
+func IsEphemeral(val cty.Value) (cty.Value, error) {
+	return IsEphemeralFunc.Call([]cty.Value{val})
+}
+
+// HasMark returns true if and only if the given value carries the named
+// mark kind ("sensitive" or "ephemeral").
+func HasMark(val cty.Value, kind cty.Value) (cty.Value, error) {
+	return HasMarkFunc.Call([]cty.Value{val, kind})
+}
@@ -0,0 +1,179 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestEphemeral(t *testing.T) {
+	tests := []struct {
+		Input   cty.Value
+		WantErr string
+	}{
+		{
+			cty.NumberIntVal(1),
+			``,
+		},
+		{
+			cty.UnknownVal(cty.String),
+			``,
+		},
+		{
+			cty.NullVal(cty.String),
+			``,
+		},
+		{
+			cty.DynamicVal,
+			``,
+		},
+		{
+			// The marking is shallow only
+			cty.ListVal([]cty.Value{cty.NumberIntVal(1)}),
+			``,
+		},
+		{
+			// A value already marked is allowed and stays marked
+			cty.NumberIntVal(1).Mark(marks.Ephemeral),
+			``,
+		},
+		{
+			// A value marked sensitive becomes ephemeral instead, the
+			// same way a stray mark would: only one first-class mark
+			// applies at a time.
+			cty.NumberIntVal(1).Mark(marks.Sensitive),
+			``,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("ephemeral(%#v)", test.Input), func(t *testing.T) {
+			got, err := Ephemeral(test.Input)
+
+			if test.WantErr != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got, want := err.Error(), test.WantErr; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.HasMark(marks.Ephemeral) {
+				t.Errorf("result is not marked ephemeral")
+			}
+
+			gotRaw, gotMarks := got.Unmark()
+			if len(gotMarks) != 1 {
+				t.Errorf("extraneous marks %#v", gotMarks)
+			}
+
+			wantRaw, _ := test.Input.Unmark()
+			if !gotRaw.RawEquals(wantRaw) {
+				t.Errorf("wrong unmarked result\ngot:  %#v\nwant: %#v", got, wantRaw)
+			}
+		})
+	}
+}
+
+func TestNonephemeral(t *testing.T) {
+	tests := []struct {
+		Input cty.Value
+	}{
+		{cty.NumberIntVal(1).Mark(marks.Ephemeral)},
+		{cty.DynamicVal.Mark(marks.Ephemeral)},
+		{cty.UnknownVal(cty.String).Mark(marks.Ephemeral)},
+		{cty.NullVal(cty.EmptyObject).Mark(marks.Ephemeral)},
+		// Passing a value that is already non-ephemeral is not an error.
+		{cty.NumberIntVal(1)},
+		{cty.NullVal(cty.String)},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("nonephemeral(%#v)", test.Input), func(t *testing.T) {
+			got, err := Nonephemeral(test.Input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.HasMark(marks.Ephemeral) {
+				t.Errorf("result is still marked ephemeral")
+			}
+			wantRaw, _ := test.Input.Unmark()
+			if !got.RawEquals(wantRaw) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Input)
+			}
+		})
+	}
+}
+
+func TestIsEphemeral(t *testing.T) {
+	tests := []struct {
+		Input       cty.Value
+		IsEphemeral bool
+	}{
+		{cty.NumberIntVal(1).Mark(marks.Ephemeral), true},
+		{cty.NumberIntVal(1), false},
+		{cty.DynamicVal.Mark(marks.Ephemeral), true},
+		{cty.DynamicVal, false},
+		{cty.NumberIntVal(1).Mark(marks.Sensitive), false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("isephemeral(%#v)", test.Input), func(t *testing.T) {
+			got, err := IsEphemeral(test.Input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.Equals(cty.BoolVal(test.IsEphemeral)).False() {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, cty.BoolVal(test.IsEphemeral))
+			}
+		})
+	}
+}
+
+func TestHasMark(t *testing.T) {
+	tests := []struct {
+		Input   cty.Value
+		Kind    string
+		Want    bool
+		WantErr string
+	}{
+		{cty.NumberIntVal(1).Mark(marks.Sensitive), "sensitive", true, ``},
+		{cty.NumberIntVal(1).Mark(marks.Sensitive), "ephemeral", false, ``},
+		{cty.NumberIntVal(1).Mark(marks.Ephemeral), "ephemeral", true, ``},
+		{cty.NumberIntVal(1), "sensitive", false, ``},
+		{cty.NumberIntVal(1), "bogus", false, `unsupported mark kind "bogus"`},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("hasmark(%#v, %q)", test.Input, test.Kind), func(t *testing.T) {
+			got, err := HasMark(test.Input, cty.StringVal(test.Kind))
+
+			if test.WantErr != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got, want := err.Error(), test.WantErr; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.Equals(cty.BoolVal(test.Want)).False() {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, cty.BoolVal(test.Want))
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+// ApplySchemaSensitivityFunc applies marks.Sensitive to val at exactly
+// the attribute paths that schema declares sensitive, including
+// attributes nested via the object, list, set, and map nesting modes.
+// The set mode is the exception: a cty set cannot hold marks inside its
+// elements, so a sensitive attribute within a set element marks the
+// whole set instead.
+// This ports the recursive marking logic that configschema.Object uses
+// internally to decide which paths to redact into a user-callable
+// primitive, so module authors can re-mark values coming from a data
+// source or jsondecode() according to a schema they already have on
+// hand, instead of calling sensitive() at every leaf by hand.
+var ApplySchemaSensitivityFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:         "value",
+			Type:         cty.DynamicPseudoType,
+			AllowNull:    true,
+			AllowUnknown: true,
+			AllowMarked:  true,
+		},
+		{
+			Name: "schema",
+			Type: cty.DynamicPseudoType,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return applySchemaSensitivity(args[0], args[1])
+	},
+})
+
+// ApplySchemaSensitivity marks val according to schema. See
+// applySchemaSensitivity for the expected shape of schema.
+func ApplySchemaSensitivity(val cty.Value, schema cty.Value) (cty.Value, error) {
+	return ApplySchemaSensitivityFunc.Call([]cty.Value{val, schema})
+}
+
+// applySchemaSensitivity recurses over val's attributes using schema,
+// an object whose keys match val's attribute names. Each value in schema
+// is itself an object of the form:
+//
+//	{
+//	  sensitive = bool
+//	  nesting   = "single" | "list" | "set" | "map" | null
+//	  nested    = <schema for the nested value's own attributes> | null
+//	}
+//
+// For a plain, non-block attribute, omit both "nesting" and "nested" (or
+// set them to null). "nesting" is ignored when "nested" is absent or null.
+// A "nested" schema with no "nesting" is treated as "single".
+func applySchemaSensitivity(val cty.Value, schema cty.Value) (cty.Value, error) {
+	if val.IsNull() || !val.IsKnown() {
+		return val, nil
+	}
+
+	raw, valMarks := val.Unmark()
+	if !raw.Type().IsObjectType() {
+		return cty.NilVal, fmt.Errorf("value must be an object, got %s", raw.Type().FriendlyName())
+	}
+	if !schema.Type().IsObjectType() {
+		return cty.NilVal, fmt.Errorf("schema must be an object describing each attribute")
+	}
+
+	attrs := make(map[string]cty.Value)
+	for name, attrVal := range raw.AsValueMap() {
+		attrSchema, ok := schemaAttr(schema, name)
+		if !ok {
+			attrs[name] = attrVal
+			continue
+		}
+
+		newVal, err := applyAttrSensitivity(attrVal, attrSchema)
+		if err != nil {
+			return cty.NilVal, fmt.Errorf("attribute %q: %w", name, err)
+		}
+		attrs[name] = newVal
+	}
+
+	return cty.ObjectVal(attrs).WithMarks(valMarks), nil
+}
+
+// applyAttrSensitivity marks a single attribute value according to its
+// own schema entry, recursing into nested blocks first so that a
+// "sensitive" flag on the containing attribute marks the whole
+// already-processed nested value.
+func applyAttrSensitivity(val cty.Value, attrSchema cty.Value) (cty.Value, error) {
+	sensitive := false
+	if sv, ok := schemaAttr(attrSchema, "sensitive"); ok && !sv.IsNull() {
+		sensitive = sv.True()
+	}
+
+	nesting := ""
+	if nv, ok := schemaAttr(attrSchema, "nesting"); ok && !nv.IsNull() {
+		nesting = nv.AsString()
+	}
+
+	result := val
+	if nested, ok := schemaAttr(attrSchema, "nested"); ok && !nested.IsNull() {
+		var err error
+		result, err = applyNestedSensitivity(val, nested, nesting)
+		if err != nil {
+			return cty.NilVal, err
+		}
+	}
+
+	if sensitive {
+		result = result.Mark(marks.Sensitive)
+	}
+	return result, nil
+}
+
+// applyNestedSensitivity dispatches on nesting to recurse into val's
+// own nested schema, mirroring configschema's NestingSingle/List/Set/Map
+// modes.
+func applyNestedSensitivity(val cty.Value, nestedSchema cty.Value, nesting string) (cty.Value, error) {
+	switch nesting {
+	case "", "single":
+		return applySchemaSensitivity(val, nestedSchema)
+	case "list":
+		return applyNestedSequence(val, nestedSchema, cty.ListVal, cty.ListValEmpty)
+	case "set":
+		return applyNestedSequence(val, nestedSchema, cty.SetVal, cty.SetValEmpty)
+	case "map":
+		return applyNestedMap(val, nestedSchema)
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported nesting mode %q", nesting)
+	}
+}
+
+// applyNestedSequence applies nestedSchema to each element of a list or
+// set value, using build to reassemble the non-empty case and buildEmpty
+// to preserve the element type when val has no elements.
+func applyNestedSequence(val cty.Value, nestedSchema cty.Value, build func([]cty.Value) cty.Value, buildEmpty func(cty.Type) cty.Value) (cty.Value, error) {
+	if val.IsNull() || !val.IsKnown() {
+		return val, nil
+	}
+
+	raw, valMarks := val.Unmark()
+
+	var elems []cty.Value
+	it := raw.ElementIterator()
+	for it.Next() {
+		_, ev := it.Element()
+		newEv, err := applySchemaSensitivity(ev, nestedSchema)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		elems = append(elems, newEv)
+	}
+
+	if len(elems) == 0 {
+		return buildEmpty(raw.Type().ElementType()).WithMarks(valMarks), nil
+	}
+	return build(elems).WithMarks(valMarks), nil
+}
+
+// applyNestedMap applies nestedSchema to each value of a map.
+func applyNestedMap(val cty.Value, nestedSchema cty.Value) (cty.Value, error) {
+	if val.IsNull() || !val.IsKnown() {
+		return val, nil
+	}
+
+	raw, valMarks := val.Unmark()
+
+	elems := make(map[string]cty.Value)
+	it := raw.ElementIterator()
+	for it.Next() {
+		kv, ev := it.Element()
+		newEv, err := applySchemaSensitivity(ev, nestedSchema)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		elems[kv.AsString()] = newEv
+	}
+
+	if len(elems) == 0 {
+		return cty.MapValEmpty(raw.Type().ElementType()).WithMarks(valMarks), nil
+	}
+	return cty.MapVal(elems).WithMarks(valMarks), nil
+}
+
+// schemaAttr returns schema's attribute named name, if schema is an
+// object type that has one.
+func schemaAttr(schema cty.Value, name string) (cty.Value, bool) {
+	ty := schema.Type()
+	if !ty.IsObjectType() || !ty.HasAttribute(name) {
+		return cty.NilVal, false
+	}
+	return schema.GetAttr(name), true
+}
@@ -0,0 +1,144 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// leafSchema builds the schema entry for a plain, non-block attribute.
+func leafSchema(sensitive bool) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"sensitive": cty.BoolVal(sensitive),
+		"nesting":   cty.NullVal(cty.String),
+		"nested":    cty.NullVal(cty.EmptyObject),
+	})
+}
+
+// blockSchema builds the schema entry for a nested-block attribute.
+func blockSchema(sensitive bool, nesting string, nested cty.Value) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"sensitive": cty.BoolVal(sensitive),
+		"nesting":   cty.StringVal(nesting),
+		"nested":    nested,
+	})
+}
+
+func TestApplySchemaSensitivity(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  cty.Value
+		schema cty.Value
+		check  func(t *testing.T, got cty.Value)
+	}{
+		{
+			name: "mixed sensitive and non-sensitive top-level attributes",
+			value: cty.ObjectVal(map[string]cty.Value{
+				"username": cty.StringVal("admin"),
+				"password": cty.StringVal("hunter2"),
+			}),
+			schema: cty.ObjectVal(map[string]cty.Value{
+				"username": leafSchema(false),
+				"password": leafSchema(true),
+			}),
+			check: func(t *testing.T, got cty.Value) {
+				if got.GetAttr("username").HasMark(marks.Sensitive) {
+					t.Errorf("username should not be sensitive")
+				}
+				if !got.GetAttr("password").HasMark(marks.Sensitive) {
+					t.Errorf("password should be sensitive")
+				}
+			},
+		},
+		{
+			name: "nested object",
+			value: cty.ObjectVal(map[string]cty.Value{
+				"creds": cty.ObjectVal(map[string]cty.Value{
+					"token": cty.StringVal("abc123"),
+					"scope": cty.StringVal("read"),
+				}),
+			}),
+			schema: cty.ObjectVal(map[string]cty.Value{
+				"creds": blockSchema(false, "single", cty.ObjectVal(map[string]cty.Value{
+					"token": leafSchema(true),
+					"scope": leafSchema(false),
+				})),
+			}),
+			check: func(t *testing.T, got cty.Value) {
+				creds := got.GetAttr("creds")
+				if !creds.GetAttr("token").HasMark(marks.Sensitive) {
+					t.Errorf("creds.token should be sensitive")
+				}
+				if creds.GetAttr("scope").HasMark(marks.Sensitive) {
+					t.Errorf("creds.scope should not be sensitive")
+				}
+			},
+		},
+		{
+			name: "list of object",
+			value: cty.ObjectVal(map[string]cty.Value{
+				"keys": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("1"), "secret": cty.StringVal("s1")}),
+					cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("2"), "secret": cty.StringVal("s2")}),
+				}),
+			}),
+			schema: cty.ObjectVal(map[string]cty.Value{
+				"keys": blockSchema(false, "list", cty.ObjectVal(map[string]cty.Value{
+					"id":     leafSchema(false),
+					"secret": leafSchema(true),
+				})),
+			}),
+			check: func(t *testing.T, got cty.Value) {
+				keys := got.GetAttr("keys")
+				it := keys.ElementIterator()
+				for it.Next() {
+					_, elem := it.Element()
+					if elem.GetAttr("id").HasMark(marks.Sensitive) {
+						t.Errorf("keys[*].id should not be sensitive")
+					}
+					if !elem.GetAttr("secret").HasMark(marks.Sensitive) {
+						t.Errorf("keys[*].secret should be sensitive")
+					}
+				}
+			},
+		},
+		{
+			name: "map of object",
+			value: cty.ObjectVal(map[string]cty.Value{
+				"tags": cty.MapVal(map[string]cty.Value{
+					"a": cty.ObjectVal(map[string]cty.Value{"value": cty.StringVal("x")}),
+					"b": cty.ObjectVal(map[string]cty.Value{"value": cty.StringVal("y")}),
+				}),
+			}),
+			schema: cty.ObjectVal(map[string]cty.Value{
+				"tags": blockSchema(false, "map", cty.ObjectVal(map[string]cty.Value{
+					"value": leafSchema(true),
+				})),
+			}),
+			check: func(t *testing.T, got cty.Value) {
+				tags := got.GetAttr("tags")
+				it := tags.ElementIterator()
+				for it.Next() {
+					_, elem := it.Element()
+					if !elem.GetAttr("value").HasMark(marks.Sensitive) {
+						t.Errorf("tags[*].value should be sensitive")
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplySchemaSensitivity(tt.value, tt.schema)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+// SensitiveFunc is a function that takes a value and returns a value
+// identical to its argument but marked so that OpenTofu will render it
+// as redacted in the UI.
+var SensitiveFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		// The mark is added to the outermost value only, not deeply. Any
+		// other shallow mark the value already carries is discarded: a
+		// value arriving here with some non-standard mark would imply an
+		// inconsistency/bug elsewhere, so we just coerce it to be marked
+		// sensitive rather than trying to combine marks of unknown
+		// significance.
+		val, _ := args[0].Unmark()
+		return val.Mark(marks.Sensitive), nil
+	},
+})
+
+// NonsensitiveFunc is a function that takes a value and returns a value
+// identical to its argument but with the "sensitive" marking removed, if
+// it was present.
+var NonsensitiveFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val, marksOnVal := args[0].Unmark()
+		delete(marksOnVal, marks.Sensitive)
+		return val.WithMarks(marksOnVal), nil
+	},
+})
+
+// IsSensitiveFunc is a function that takes a value and returns true if
+// and only if that value is marked as sensitive.
+var IsSensitiveFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.BoolVal(args[0].HasMark(marks.Sensitive)), nil
+	},
+})
+
+// Sensitive marks a value as sensitive, which OpenTofu will then treat as
+// if it had been derived from a value the user explicitly asked to keep
+// secret.
+func Sensitive(val cty.Value) (cty.Value, error) {
+	return SensitiveFunc.Call([]cty.Value{val})
+}
+
+// Nonsensitive removes the "sensitive" mark from a value, if it was
+// present, leaving any other marks untouched.
+func Nonsensitive(val cty.Value) (cty.Value, error) {
+	return NonsensitiveFunc.Call([]cty.Value{val})
+}
+
+// IsSensitive returns true if and only if the given value is marked as
+// sensitive.
+func IsSensitive(val cty.Value) (cty.Value, error) {
+	return IsSensitiveFunc.Call([]cty.Value{val})
+}
+
+// MaskSensitiveFunc is a function that takes a value and an optional
+// replacement value, and returns a copy of the value with every
+// sensitive leaf replaced by the replacement (defaulting to the string
+// "(sensitive)") and the sensitive mark removed, so that the result is
+// safe to pass to jsonencode, yamlencode, or a file-writing provisioner.
+var MaskSensitiveFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	VarParam: &function.Parameter{
+		Name:             "replacement",
+		Type:             cty.DynamicPseudoType,
+		AllowNull:        true,
+		AllowUnknown:     true,
+		AllowMarked:      true,
+		AllowDynamicType: true,
+	},
+	// A masked leaf takes the replacement's type, so the result's type can
+	// differ from the argument's.
+	Type: function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		replacement := cty.StringVal("(sensitive)")
+		if len(args) > 1 {
+			replacement = args[1]
+		}
+		return MaskSensitive(args[0], replacement)
+	},
+})
+
+// MaskSensitive walks val and, at every leaf carrying marks.Sensitive,
+// substitutes replacement and strips the mark, while preserving any
+// other marks the leaf carries. An unknown or null val, or one of the
+// dynamic pseudo-type, can't itself be walked into, so it's returned
+// unchanged unless it's directly marked sensitive; but a val that is
+// known and typed still gets walked even if some part of it is unknown,
+// since cty.Transform can mask the known sensitive leaves of a partially
+// unknown structure just fine, and skipping that would leak them.
+func MaskSensitive(val cty.Value, replacement cty.Value) (cty.Value, error) {
+	if val.HasMark(marks.Sensitive) {
+		_, otherMarks := val.Unmark()
+		delete(otherMarks, marks.Sensitive)
+		return replacement.WithMarks(otherMarks), nil
+	}
+
+	if val.Type() == cty.DynamicPseudoType || !val.IsKnown() {
+		return val, nil
+	}
+
+	return cty.Transform(val, func(_ cty.Path, v cty.Value) (cty.Value, error) {
+		if !v.HasMark(marks.Sensitive) {
+			return v, nil
+		}
+		_, otherMarks := v.Unmark()
+		delete(otherMarks, marks.Sensitive)
+		return replacement.WithMarks(otherMarks), nil
+	})
+}
+
+// MaskSensitiveDefault masks val's sensitive leaves using the default
+// replacement value "(sensitive)".
+func MaskSensitiveDefault(val cty.Value) (cty.Value, error) {
+	return MaskSensitiveFunc.Call([]cty.Value{val})
+}
+
+// FlipSensitive toggles the sensitivity of val: a value that isn't
+// currently marked sensitive becomes sensitive, and a value that is
+// already marked sensitive has that mark removed. Unlike Sensitive, which
+// discards any other shallow marks on its way to enforcing a single
+// canonical sensitive marking, FlipSensitive preserves whatever other
+// marks val already carries; it only ever adds or removes
+// marks.Sensitive.
+func FlipSensitive(val cty.Value) (cty.Value, error) {
+	if marks.Has(val, marks.Sensitive) {
+		return Nonsensitive(val)
+	}
+	return val.Mark(marks.Sensitive), nil
+}
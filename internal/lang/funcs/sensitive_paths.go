@@ -0,0 +1,181 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+// SensitivePathsFunc is a function that takes a value and returns the
+// list of attribute paths, as strings, at which a marks.Sensitive mark
+// currently lives somewhere within that value. This mirrors the
+// cty.PathValueMarks bookkeeping OpenTofu already does internally when
+// encoding a plan (BeforeValMarks/AfterValMarks), but exposes it as a
+// value that module authors can inspect directly.
+var SensitivePathsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		_, pvm := args[0].UnmarkDeepWithPaths()
+
+		var paths []cty.Value
+		for _, pv := range pvm {
+			if _, sensitive := pv.Marks[marks.Sensitive]; !sensitive {
+				continue
+			}
+			paths = append(paths, cty.StringVal(formatMarkPath(pv.Path)))
+		}
+
+		if len(paths) == 0 {
+			return cty.ListValEmpty(cty.String), nil
+		}
+		return cty.ListVal(paths), nil
+	},
+})
+
+// RedactFunc is a function that takes a value and a list of attribute
+// paths (in the same string form produced by SensitivePathsFunc) and
+// returns a copy of the value with marks.Sensitive applied at exactly
+// those paths, using cty.Value.MarkWithPaths.
+var RedactFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowNull:        true,
+			AllowUnknown:     true,
+			AllowMarked:      true,
+			AllowDynamicType: true,
+		},
+		{
+			Name: "paths",
+			Type: cty.List(cty.String),
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		return args[0].Type(), nil
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val := args[0]
+
+		var pvms []cty.PathValueMarks
+		it := args[1].ElementIterator()
+		for it.Next() {
+			_, pathVal := it.Element()
+			if pathVal.IsNull() {
+				continue
+			}
+			path, err := parseMarkPath(pathVal.AsString())
+			if err != nil {
+				return cty.NilVal, fmt.Errorf("invalid path %q: %w", pathVal.AsString(), err)
+			}
+			pvms = append(pvms, cty.PathValueMarks{
+				Path:  path,
+				Marks: cty.NewValueMarks(marks.Sensitive),
+			})
+		}
+
+		marked := val.MarkWithPaths(pvms)
+		return marked, nil
+	},
+})
+
+// SensitivePaths returns the list of attribute paths, as strings, where
+// val currently carries a marks.Sensitive mark.
+func SensitivePaths(val cty.Value) (cty.Value, error) {
+	return SensitivePathsFunc.Call([]cty.Value{val})
+}
+
+// Redact returns a copy of val with marks.Sensitive applied at each of
+// the given attribute paths.
+func Redact(val, paths cty.Value) (cty.Value, error) {
+	return RedactFunc.Call([]cty.Value{val, paths})
+}
+
+// formatMarkPath renders a cty.Path in the same dotted/bracketed notation
+// accepted by "try" and HCL traversals, e.g. `.foo.bar[0]["baz"]`, so
+// that the strings returned by sensitivepaths() can be fed straight back
+// into redact() or used in other path-based expressions.
+func formatMarkPath(path cty.Path) string {
+	var buf strings.Builder
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			buf.WriteByte('.')
+			buf.WriteString(s.Name)
+		case cty.IndexStep:
+			buf.WriteByte('[')
+			switch s.Key.Type() {
+			case cty.String:
+				buf.WriteString(strconv.Quote(s.Key.AsString()))
+			case cty.Number:
+				bf := s.Key.AsBigFloat()
+				buf.WriteString(bf.Text('f', -1))
+			}
+			buf.WriteByte(']')
+		}
+	}
+	return buf.String()
+}
+
+// parseMarkPath is the inverse of formatMarkPath for attribute names that
+// contain neither '.' nor '[', and for string keys that contain no ']'.
+// Other paths are split in the wrong place or rejected.
+func parseMarkPath(s string) (cty.Path, error) {
+	var path cty.Path
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			i := strings.IndexAny(s, ".[")
+			if i == -1 {
+				i = len(s)
+			}
+			if i == 0 {
+				return nil, fmt.Errorf("empty attribute name")
+			}
+			path = append(path, cty.GetAttrStep{Name: s[:i]})
+			s = s[i:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated index step")
+			}
+			raw := s[1:end]
+			s = s[end+1:]
+			if strings.HasPrefix(raw, `"`) {
+				key, err := strconv.Unquote(raw)
+				if err != nil {
+					return nil, fmt.Errorf("invalid string index %s: %w", raw, err)
+				}
+				path = append(path, cty.IndexStep{Key: cty.StringVal(key)})
+			} else {
+				n, err := strconv.ParseInt(raw, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid numeric index %s: %w", raw, err)
+				}
+				path = append(path, cty.IndexStep{Key: cty.NumberIntVal(n)})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected character %q", s[0])
+		}
+	}
+	return path, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSensitivePaths(t *testing.T) {
+	tests := []struct {
+		Input cty.Value
+		Want  []string
+	}{
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("x"),
+				"b": cty.StringVal("y").Mark(marks.Sensitive),
+			}),
+			[]string{".b"},
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"list": cty.ListVal([]cty.Value{
+					cty.StringVal("x"),
+					cty.StringVal("y").Mark(marks.Sensitive),
+				}),
+			}),
+			[]string{".list[1]"},
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("x"),
+			}),
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("sensitivepaths(%#v)", test.Input), func(t *testing.T) {
+			got, err := SensitivePaths(test.Input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var gotPaths []string
+			it := got.ElementIterator()
+			for it.Next() {
+				_, v := it.Element()
+				gotPaths = append(gotPaths, v.AsString())
+			}
+
+			if len(gotPaths) != len(test.Want) {
+				t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", gotPaths, test.Want)
+			}
+			for i := range test.Want {
+				if gotPaths[i] != test.Want[i] {
+					t.Errorf("wrong path at %d\ngot:  %s\nwant: %s", i, gotPaths[i], test.Want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	input := cty.ObjectVal(map[string]cty.Value{
+		"a": cty.StringVal("x"),
+		"b": cty.StringVal("y"),
+	})
+	paths := cty.ListVal([]cty.Value{cty.StringVal(".b")})
+
+	got, err := Redact(input, paths)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	gotB := got.GetAttr("b")
+	if !gotB.HasMark(marks.Sensitive) {
+		t.Errorf("expected .b to be marked sensitive")
+	}
+	gotA := got.GetAttr("a")
+	if gotA.HasMark(marks.Sensitive) {
+		t.Errorf(".a should not be marked sensitive")
+	}
+}
@@ -368,7 +368,7 @@ func TestFlipSensitive(t *testing.T) {
 
 			// Check sensitivity
 			if marks.Has(got, marks.Sensitive) != marks.Has(tt.expected, marks.Sensitive) {
-				t.Errorf("FlipSensitive() sensitivity mismatch: got %v, want %v", 
+				t.Errorf("FlipSensitive() sensitivity mismatch: got %v, want %v",
 					marks.Has(got, marks.Sensitive), marks.Has(tt.expected, marks.Sensitive))
 			}
 		})
@@ -450,3 +450,119 @@ func TestFlipSensitiveWithMarks(t *testing.T) {
 	}
 }
 
+func TestMaskSensitive(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       cty.Value
+		replacement cty.Value
+		expected    cty.Value
+	}{
+		{
+			name:        "non-sensitive value is untouched",
+			input:       cty.StringVal("hello"),
+			replacement: cty.StringVal("(sensitive)"),
+			expected:    cty.StringVal("hello"),
+		},
+		{
+			name:        "sensitive scalar is replaced",
+			input:       cty.StringVal("secret").Mark(marks.Sensitive),
+			replacement: cty.StringVal("(sensitive)"),
+			expected:    cty.StringVal("(sensitive)"),
+		},
+		{
+			name: "sensitive leaf within an object is replaced",
+			input: cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("x"),
+				"b": cty.StringVal("y").Mark(marks.Sensitive),
+			}),
+			replacement: cty.StringVal("(sensitive)"),
+			expected: cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("x"),
+				"b": cty.StringVal("(sensitive)"),
+			}),
+		},
+		{
+			name: "sensitive leaf is masked even alongside an unknown sibling",
+			input: cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("secret").Mark(marks.Sensitive),
+				"b": cty.UnknownVal(cty.String),
+			}),
+			replacement: cty.StringVal("(sensitive)"),
+			expected: cty.ObjectVal(map[string]cty.Value{
+				"a": cty.StringVal("(sensitive)"),
+				"b": cty.UnknownVal(cty.String),
+			}),
+		},
+		{
+			name:        "unknown value is untouched",
+			input:       cty.UnknownVal(cty.String),
+			replacement: cty.StringVal("(sensitive)"),
+			expected:    cty.UnknownVal(cty.String),
+		},
+		{
+			name:        "null value is untouched",
+			input:       cty.NullVal(cty.String),
+			replacement: cty.StringVal("(sensitive)"),
+			expected:    cty.NullVal(cty.String),
+		},
+		{
+			name:        "dynamic value is untouched",
+			input:       cty.DynamicVal,
+			replacement: cty.StringVal("(sensitive)"),
+			expected:    cty.DynamicVal,
+		},
+		{
+			name:        "sensitive unknown value is replaced",
+			input:       cty.UnknownVal(cty.String).Mark(marks.Sensitive),
+			replacement: cty.StringVal("(sensitive)"),
+			expected:    cty.StringVal("(sensitive)"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MaskSensitive(tt.input, tt.replacement)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.RawEquals(tt.expected) {
+				t.Errorf("MaskSensitive() = %#v, want %#v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMaskSensitiveWithMarks(t *testing.T) {
+	const customMark = "custom"
+
+	// A non-sensitive custom mark carried alongside marks.Sensitive must
+	// survive the masking, the same way FlipSensitive preserves it.
+	input := cty.StringVal("multi-marked").Mark(customMark).Mark(marks.Sensitive)
+
+	got, err := MaskSensitive(input, cty.StringVal("(sensitive)"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if marks.Has(got, marks.Sensitive) {
+		t.Errorf("MaskSensitive() result is still marked sensitive")
+	}
+	if !got.HasMark(customMark) {
+		t.Errorf("MaskSensitive() did not preserve custom mark")
+	}
+
+	raw, _ := got.Unmark()
+	if !raw.RawEquals(cty.StringVal("(sensitive)")) {
+		t.Errorf("MaskSensitive() = %#v, want %#v", raw, cty.StringVal("(sensitive)"))
+	}
+}
+
+func TestMaskSensitiveDefault(t *testing.T) {
+	got, err := MaskSensitiveDefault(cty.StringVal("secret").Mark(marks.Sensitive))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !got.RawEquals(cty.StringVal("(sensitive)")) {
+		t.Errorf("MaskSensitiveDefault() = %#v, want %#v", got, cty.StringVal("(sensitive)"))
+	}
+}
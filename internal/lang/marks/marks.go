@@ -0,0 +1,57 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package marks
+
+import (
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// valueMarks allow creating strictly typed values for use as cty.Value
+// marks.
+type valueMarks string
+
+func (m valueMarks) GoString() string {
+	return "marks." + strings.Title(string(m))
+}
+
+const (
+	// Sensitive indicates that this value is or derives from something
+	// the user has asked OpenTofu to treat as sensitive, and so it should
+	// be redacted from the UI. A sensitive value is still safe to persist
+	// to the state and plan files.
+	Sensitive = valueMarks("sensitive")
+
+	// Ephemeral indicates that this value must not outlive the current
+	// operation: it must never be written to the state file or the saved
+	// plan file. Unlike Sensitive, an ephemeral value isn't necessarily
+	// secret, but persisting it would be meaningless (e.g. a short-lived
+	// token) or actively unsafe.
+	Ephemeral = valueMarks("ephemeral")
+)
+
+// TypedMarks enumerates the first-class marks known to this package, in
+// the order they should be considered when a value carries more than
+// one. Callers that need to recognize a mark kind generically (rather
+// than hard-coding marks.Sensitive or marks.Ephemeral) should range over
+// this slice instead of switching on individual mark values; hasmark()
+// in lang/funcs is the motivating example.
+//
+// Nothing in this module enforces Ephemeral: no code here rejects an
+// ephemeral value when writing the state or plan file.
+var TypedMarks = []valueMarks{
+	Sensitive,
+	Ephemeral,
+}
+
+// Has returns true if and only if the cty.Value has the given mark.
+func Has(val cty.Value, mark valueMarks) bool {
+	if !val.IsMarked() {
+		return false
+	}
+	return val.HasMark(mark)
+}